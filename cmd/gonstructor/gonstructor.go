@@ -4,7 +4,6 @@ import (
 	"flag"
 	"fmt"
 	"go/ast"
-	"go/parser"
 	"go/token"
 	"go/types"
 	"io/ioutil"
@@ -14,26 +13,41 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/BurntSushi/toml"
 	"github.com/iancoleman/strcase"
 	g "github.com/moznion/gowrtr/generator"
 	"golang.org/x/tools/go/packages"
+	"gopkg.in/yaml.v2"
 )
 
 const (
-	allArgsConstructorType = "allArgs"
-	builderConstructorType = "builder"
-	gonstructorTag         = "gonstructor"
+	allArgsConstructorType           = "allArgs"
+	builderConstructorType           = "builder"
+	functionalOptionsConstructorType = "functionalOptions"
+	allArgsCheckedConstructorType    = "allArgsChecked"
+	gonstructorTag                   = "gonstructor"
+	requiredFieldTagValue            = "required"
+	validateTagPrefix                = "validate="
 )
 
 var (
-	typeName         = flag.String("type", "", "[mandatory] a type name")
+	typeName         = flag.String("type", "", "[mandatory unless -config is given] a type name")
 	output           = flag.String("output", "", "[optional] output file name; default srcdir/<type>_gen.go")
-	constructorTypes = flag.String("constructorTypes", allArgsConstructorType, fmt.Sprintf("[optional] comma-separated list of constructor types; it expects `%s` and `%s`", allArgsConstructorType, builderConstructorType))
+	constructorTypes = flag.String("constructorTypes", allArgsConstructorType, fmt.Sprintf("[optional] comma-separated list of constructor types; it expects `%s`, `%s`, `%s` and `%s`", allArgsConstructorType, builderConstructorType, functionalOptionsConstructorType, allArgsCheckedConstructorType))
+	configPath       = flag.String("config", "", "[optional] a path to a YAML (or, with a .toml extension, TOML) config file that drives generation for multiple types in one invocation; when given, -type/-output/-constructorTypes are ignored")
+	contexts         = flag.String("contexts", "", "[optional] comma-separated list of <goos>-<goarch>[-cgo] build contexts to union fields across, e.g. `linux-amd64,windows-amd64,darwin-arm64-cgo`")
 )
 
 func main() {
 	flag.Parse()
 
+	if *configPath != "" {
+		if err := runWithConfig(*configPath, flag.Args()); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	if *typeName == "" {
 		flag.Usage()
 		os.Exit(2)
@@ -51,97 +65,243 @@ func main() {
 		args = []string{"."}
 	}
 
+	if *contexts != "" {
+		if err := runWithContexts(*typeName, args, constructorTypes); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	pkg, err := parsePackage(args)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	astFiles, err := parseFiles(pkg.GoFiles)
+	fields, typeParams, err := extractFieldsForConstructorFromASTs(*typeName, pkg.Syntax, pkg.TypesInfo)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	fields, err := extractFieldsForConstructorFromASTs(*typeName, astFiles)
-	if err != nil {
+	root := newGenerationRoot(pkg.Name, strings.Join(os.Args[1:], " "))
+	root = appendConstructorStatements(root, *typeName, fields, typeParams, constructorTypes)
+
+	filenameToGenerate := *output
+	if filenameToGenerate == "" {
+		var dir string
+		if len(args) == 1 && isDirectory(args[0]) {
+			dir = args[0]
+		} else {
+			dir = filepath.Dir(args[0])
+		}
+		filenameToGenerate = fmt.Sprintf("%s/%s_gen.go", dir, strcase.ToSnake(*typeName))
+	}
+
+	if err := generateFile(root, filenameToGenerate); err != nil {
 		log.Fatal(err)
 	}
+}
 
-	root := g.NewRoot(
-		g.NewComment(fmt.Sprintf(" Code generated by gonstructor %s; DO NOT EDIT.", strings.Join(os.Args[1:], " "))),
+func newGenerationRoot(packageName, invocation string) *g.Root {
+	return g.NewRoot(
+		g.NewComment(fmt.Sprintf(" Code generated by gonstructor %s; DO NOT EDIT.", invocation)),
 		g.NewNewline(),
-		g.NewPackage(pkg.Name),
+		g.NewPackage(packageName),
 		g.NewNewline(),
 	)
+}
 
+func appendConstructorStatements(root *g.Root, typeName string, fields []*fieldForConstructor, typeParams []*typeParam, constructorTypes []string) *g.Root {
 	for _, constructorType := range constructorTypes {
 		switch constructorType {
 		case allArgsConstructorType:
-			root = root.AddStatements(generateAllArgsConstructor(*typeName, fields))
+			root = root.AddStatements(generateAllArgsConstructor(typeName, fields, typeParams))
 		case builderConstructorType:
-			root = root.AddStatements(generateBuilderConstructor(*typeName, fields))
+			root = root.AddStatements(generateBuilderConstructor(typeName, fields, typeParams))
+		case functionalOptionsConstructorType:
+			root = root.AddStatements(generateFunctionalOptionsConstructor(typeName, fields, typeParams))
+		case allArgsCheckedConstructorType:
+			root = root.AddStatements(generateAllArgsCheckedConstructor(typeName, fields, typeParams))
 		default:
 			// unreachable, just in case
 			log.Fatalf("unexpected constructor type has come [given=%s]", constructorType)
 		}
 	}
+	return root
+}
 
-	code, err := root.EnableGoimports().EnableSyntaxChecking().Generate(0)
+// genConfig is the shape of the file given via -config (YAML or TOML, dispatched by
+// extension), letting a project describe constructor generation for many types in one place.
+type genConfig struct {
+	// Output, when set, merges every entry into this single file instead of writing
+	// one `_gen.go` per type.
+	Output  string           `yaml:"output" toml:"output"`
+	Entries []genConfigEntry `yaml:"entries" toml:"entries"`
+}
+
+type genConfigEntry struct {
+	Type             string            `yaml:"type" toml:"type"`
+	ConstructorTypes []string          `yaml:"constructorTypes" toml:"constructorTypes"`
+	Ignore           []string          `yaml:"ignore" toml:"ignore"`
+	Rename           map[string]string `yaml:"rename" toml:"rename"`
+	Output           string            `yaml:"output" toml:"output"`
+}
+
+func loadGenConfig(path string) (*genConfig, error) {
+	raw, err := ioutil.ReadFile(path)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	filenameToGenerate := ""
-	if *output == "" {
-		var dir string
-		if len(args) == 1 && isDirectory(args[0]) {
-			dir = args[0]
-		} else {
-			dir = filepath.Dir(args[0])
+	cfg := &genConfig{}
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		if err := toml.Unmarshal(raw, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config file [path=%s]: %w", path, err)
 		}
-		filenameToGenerate = fmt.Sprintf("%s/%s_gen.go", dir, strcase.ToSnake(*typeName))
 	} else {
-		filenameToGenerate = *output
+		if err := yaml.Unmarshal(raw, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config file [path=%s]: %w", path, err)
+		}
+	}
+
+	for i, entry := range cfg.Entries {
+		if entry.Type == "" {
+			return nil, fmt.Errorf("config entry #%d is missing a `type`", i)
+		}
+		if len(entry.ConstructorTypes) == 0 {
+			cfg.Entries[i].ConstructorTypes = []string{allArgsConstructorType}
+		}
+		if err := validateConstructorTypeCombination(cfg.Entries[i].ConstructorTypes); err != nil {
+			return nil, fmt.Errorf("config entry #%d [type=%s]: %w", i, entry.Type, err)
+		}
+	}
+	return cfg, nil
+}
+
+// runWithConfig drives generation for every entry in the config file given via -config,
+// parsing the package once and reusing it across entries.
+func runWithConfig(path string, args []string) error {
+	cfg, err := loadGenConfig(path)
+	if err != nil {
+		return err
+	}
+
+	if len(args) <= 0 {
+		args = []string{"."}
+	}
+
+	pkg, err := parsePackage(args)
+	if err != nil {
+		return err
+	}
+
+	var dir string
+	if len(args) == 1 && isDirectory(args[0]) {
+		dir = args[0]
+	} else {
+		dir = filepath.Dir(args[0])
+	}
+
+	invocation := strings.Join(os.Args[1:], " ")
+
+	var mergedRoot *g.Root
+	if cfg.Output != "" {
+		mergedRoot = newGenerationRoot(pkg.Name, invocation)
+	}
+
+	for _, entry := range cfg.Entries {
+		fields, typeParams, err := extractFieldsForConstructorFromASTs(entry.Type, pkg.Syntax, pkg.TypesInfo)
+		if err != nil {
+			return err
+		}
+		applyGenConfigOverrides(fields, entry)
+
+		if mergedRoot != nil {
+			mergedRoot = appendConstructorStatements(mergedRoot, entry.Type, fields, typeParams, entry.ConstructorTypes)
+			continue
+		}
+
+		root := newGenerationRoot(pkg.Name, invocation)
+		root = appendConstructorStatements(root, entry.Type, fields, typeParams, entry.ConstructorTypes)
+
+		filenameToGenerate := entry.Output
+		if filenameToGenerate == "" {
+			filenameToGenerate = fmt.Sprintf("%s/%s_gen.go", dir, strcase.ToSnake(entry.Type))
+		}
+		if err := generateFile(root, filenameToGenerate); err != nil {
+			return err
+		}
+	}
+
+	if mergedRoot != nil {
+		return generateFile(mergedRoot, cfg.Output)
+	}
+	return nil
+}
+
+func applyGenConfigOverrides(fields []*fieldForConstructor, entry genConfigEntry) {
+	ignored := make(map[string]bool, len(entry.Ignore))
+	for _, name := range entry.Ignore {
+		ignored[name] = true
+	}
+
+	for _, field := range fields {
+		if ignored[field.fieldName] {
+			field.shouldIgnore = true
+		}
+		if renamed, ok := entry.Rename[field.fieldName]; ok {
+			field.displayName = renamed
+		}
 	}
+}
 
-	err = ioutil.WriteFile(filenameToGenerate, []byte(code), 0644)
+func generateFile(root *g.Root, filename string) error {
+	code, err := root.EnableGoimports().EnableSyntaxChecking().Generate(0)
 	if err != nil {
-		log.Fatal(fmt.Errorf("[error] failed output generated code to a file: %w", err))
+		return err
 	}
+
+	if err := ioutil.WriteFile(filename, []byte(code), 0644); err != nil {
+		return fmt.Errorf("[error] failed output generated code to a file: %w", err)
+	}
+	return nil
 }
 
-func generateAllArgsConstructor(typeName string, fields []*fieldForConstructor) g.Statement {
-	funcSignature := g.NewFuncSignature(fmt.Sprintf("New%s", strcase.ToCamel(typeName)))
+func generateAllArgsConstructor(typeName string, fields []*fieldForConstructor, typeParams []*typeParam) g.Statement {
+	typeInstance := typeName + typeParamsUse(typeParams)
+	funcSignature := g.NewFuncSignature(fmt.Sprintf("New%s%s", strcase.ToCamel(typeName), typeParamsDecl(typeParams)))
 	items := make([]string, 0)
 
 	for _, field := range fields {
 		if field.shouldIgnore {
 			continue
 		}
-		funcSignature = funcSignature.AddFuncParameters(g.NewFuncParameter(strcase.ToLowerCamel(field.fieldName), field.fieldType))
-		items = append(items, fmt.Sprintf("%s: %s", field.fieldName, strcase.ToLowerCamel(field.fieldName)))
+		funcSignature = funcSignature.AddFuncParameters(g.NewFuncParameter(strcase.ToLowerCamel(field.displayName), field.fieldType))
+		items = append(items, fmt.Sprintf("%s: %s", field.fieldName, strcase.ToLowerCamel(field.displayName)))
 	}
 
-	funcSignature = funcSignature.AddReturnTypes("*" + typeName)
+	funcSignature = funcSignature.AddReturnTypes("*" + typeInstance)
 
 	return g.NewFunc(
 		nil,
 		funcSignature,
-		g.NewReturnStatement(fmt.Sprintf("&%s{%s}", typeName, strings.Join(items, ","))),
+		g.NewReturnStatement(fmt.Sprintf("&%s{%s}", typeInstance, strings.Join(items, ","))),
 	)
 }
 
-func generateBuilderConstructor(typeName string, fields []*fieldForConstructor) g.Statement {
-	builderConstructorName := fmt.Sprintf("New%sBuilder", strcase.ToCamel(typeName))
+func generateBuilderConstructor(typeName string, fields []*fieldForConstructor, typeParams []*typeParam) g.Statement {
+	typeInstance := typeName + typeParamsUse(typeParams)
+	builderConstructorName := fmt.Sprintf("New%sBuilder%s", strcase.ToCamel(typeName), typeParamsDecl(typeParams))
 	builderType := fmt.Sprintf("%sBuilder", strcase.ToCamel(typeName))
+	builderTypeInstance := builderType + typeParamsUse(typeParams)
 
 	builderConstructorFunc :=
 		g.NewFunc(
 			nil,
-			g.NewFuncSignature(builderConstructorName).AddReturnTypes(fmt.Sprintf("*%s", builderType)),
-			g.NewReturnStatement(fmt.Sprintf("&%s{}", builderType)),
+			g.NewFuncSignature(builderConstructorName).AddReturnTypes(fmt.Sprintf("*%s", builderTypeInstance)),
+			g.NewReturnStatement(fmt.Sprintf("&%s{}", builderTypeInstance)),
 		)
 
-	builderStruct := g.NewStruct(builderType)
+	builderStruct := g.NewStruct(builderType + typeParamsDecl(typeParams))
 	builderFieldFuncs := make([]*g.Func, 0)
 	items := make([]string, 0)
 	for _, field := range fields {
@@ -149,71 +309,245 @@ func generateBuilderConstructor(typeName string, fields []*fieldForConstructor)
 			continue
 		}
 		builderStruct = builderStruct.AddField(
-			strcase.ToLowerCamel(field.fieldName),
+			strcase.ToLowerCamel(field.displayName),
 			field.fieldType,
 		)
 
 		builderFieldFuncs = append(builderFieldFuncs, g.NewFunc(
-			g.NewFuncReceiver("b", "*"+builderType),
-			g.NewFuncSignature(strcase.ToCamel(field.fieldName)).
-				AddFuncParameters(g.NewFuncParameter(strcase.ToLowerCamel(field.fieldName), field.fieldType)).
-				AddReturnTypes("*"+builderType),
-			g.NewRawStatement(fmt.Sprintf("b.%s = %s", strcase.ToLowerCamel(field.fieldName), strcase.ToLowerCamel(field.fieldName))),
+			g.NewFuncReceiver("b", "*"+builderTypeInstance),
+			g.NewFuncSignature(strcase.ToCamel(field.displayName)).
+				AddFuncParameters(g.NewFuncParameter(strcase.ToLowerCamel(field.displayName), field.fieldType)).
+				AddReturnTypes("*"+builderTypeInstance),
+			g.NewRawStatement(fmt.Sprintf("b.%s = %s", strcase.ToLowerCamel(field.displayName), strcase.ToLowerCamel(field.displayName))),
 			g.NewReturnStatement("b"),
 		))
 
-		items = append(items, fmt.Sprintf("%s: b.%s", field.fieldName, strcase.ToLowerCamel(field.fieldName)))
+		items = append(items, fmt.Sprintf("%s: b.%s", field.fieldName, strcase.ToLowerCamel(field.displayName)))
 	}
 
 	root := g.NewRoot(builderStruct, builderConstructorFunc)
 	for _, f := range builderFieldFuncs {
 		root = root.AddStatements(f)
 	}
-	root = root.AddStatements(
-		g.NewFunc(
-			g.NewFuncReceiver("b", "*"+builderType),
+
+	buildResult := fmt.Sprintf("&%s{%s}", typeInstance, strings.Join(items, ","))
+	if needsValidation(fields) {
+		buildStatements := make([]g.Statement, 0)
+		for _, field := range fields {
+			if field.shouldIgnore {
+				continue
+			}
+			buildStatements = append(buildStatements, generateValidationStatements(field, "b."+strcase.ToLowerCamel(field.displayName))...)
+		}
+		buildStatements = append(buildStatements, g.NewReturnStatement(fmt.Sprintf("%s, nil", buildResult)))
+
+		root = root.AddStatements(g.NewFunc(
+			g.NewFuncReceiver("b", "*"+builderTypeInstance),
 			g.NewFuncSignature("Build").
-				AddReturnTypes("*"+typeName),
-			g.NewReturnStatement(fmt.Sprintf("&%s{%s}", typeName, strings.Join(items, ","))),
-		),
+				AddReturnTypes("*"+typeInstance, "error"),
+			buildStatements...,
+		))
+	} else {
+		root = root.AddStatements(g.NewFunc(
+			g.NewFuncReceiver("b", "*"+builderTypeInstance),
+			g.NewFuncSignature("Build").
+				AddReturnTypes("*"+typeInstance),
+			g.NewReturnStatement(buildResult),
+		))
+	}
+
+	return root
+}
+
+func generateFunctionalOptionsConstructor(typeName string, fields []*fieldForConstructor, typeParams []*typeParam) g.Statement {
+	typeInstance := typeName + typeParamsUse(typeParams)
+	optionType := fmt.Sprintf("%sOption", strcase.ToCamel(typeName))
+	optionTypeInstance := optionType + typeParamsUse(typeParams)
+	receiverName := strcase.ToLowerCamel(typeName)
+	// typeName lower-cameling to "opts" (a type named Opts) would otherwise collide with the
+	// variadic parameter, and to "opt" (a type named Opt) with the loop variable, shadowing
+	// the receiver it calls.
+	optsParamName := distinctIdent("opts", receiverName)
+	loopVarName := distinctIdent("opt", receiverName, optsParamName)
+
+	optionTypeDecl := g.NewRawStatement(fmt.Sprintf("type %s%s func(*%s)", optionType, typeParamsDecl(typeParams), typeInstance))
+
+	funcSignature := g.NewFuncSignature(fmt.Sprintf("New%s%s", strcase.ToCamel(typeName), typeParamsDecl(typeParams)))
+	items := make([]string, 0)
+	for _, field := range fields {
+		if field.shouldIgnore || !field.required {
+			continue
+		}
+		funcSignature = funcSignature.AddFuncParameters(g.NewFuncParameter(strcase.ToLowerCamel(field.displayName), field.fieldType))
+		items = append(items, fmt.Sprintf("%s: %s", field.fieldName, strcase.ToLowerCamel(field.displayName)))
+	}
+	funcSignature = funcSignature.
+		AddFuncParameters(g.NewFuncParameter(optsParamName, "..."+optionTypeInstance)).
+		AddReturnTypes("*" + typeInstance)
+
+	constructorFunc := g.NewFunc(
+		nil,
+		funcSignature,
+		g.NewRawStatement(fmt.Sprintf("%s := &%s{%s}", receiverName, typeInstance, strings.Join(items, ","))),
+		g.NewRawStatement(fmt.Sprintf("for _, %s := range %s { %s(%s) }", loopVarName, optsParamName, loopVarName, receiverName)),
+		g.NewReturnStatement(receiverName),
 	)
 
+	root := g.NewRoot(optionTypeDecl, constructorFunc)
+	for _, field := range fields {
+		if field.shouldIgnore || field.required {
+			continue
+		}
+
+		root = root.AddStatements(g.NewFunc(
+			nil,
+			g.NewFuncSignature(fmt.Sprintf("With%s%s", strcase.ToCamel(field.displayName), typeParamsDecl(typeParams))).
+				AddFuncParameters(g.NewFuncParameter(strcase.ToLowerCamel(field.displayName), field.fieldType)).
+				AddReturnTypes(optionTypeInstance),
+			g.NewReturnStatement(fmt.Sprintf("func(%s *%s) { %s.%s = %s }", receiverName, typeInstance, receiverName, field.fieldName, strcase.ToLowerCamel(field.displayName))),
+		))
+	}
+
 	return root
 }
 
+// distinctIdent returns preferred, or preferred with a trailing underscore appended if it
+// collides with any of taken.
+func distinctIdent(preferred string, taken ...string) string {
+	for _, t := range taken {
+		if preferred == t {
+			return preferred + "_"
+		}
+	}
+	return preferred
+}
+
+// generateAllArgsCheckedConstructor is like generateAllArgsConstructor, but New<Type>
+// returns (*Type, error) after running each field's validation rules.
+func generateAllArgsCheckedConstructor(typeName string, fields []*fieldForConstructor, typeParams []*typeParam) g.Statement {
+	typeInstance := typeName + typeParamsUse(typeParams)
+	funcSignature := g.NewFuncSignature(fmt.Sprintf("New%s%s", strcase.ToCamel(typeName), typeParamsDecl(typeParams)))
+	items := make([]string, 0)
+	statements := make([]g.Statement, 0)
+
+	for _, field := range fields {
+		if field.shouldIgnore {
+			continue
+		}
+		varName := strcase.ToLowerCamel(field.displayName)
+		funcSignature = funcSignature.AddFuncParameters(g.NewFuncParameter(varName, field.fieldType))
+		items = append(items, fmt.Sprintf("%s: %s", field.fieldName, varName))
+		statements = append(statements, generateValidationStatements(field, varName)...)
+	}
+
+	funcSignature = funcSignature.AddReturnTypes("*"+typeInstance, "error")
+	statements = append(statements, g.NewReturnStatement(fmt.Sprintf("&%s{%s}, nil", typeInstance, strings.Join(items, ","))))
+
+	return g.NewFunc(nil, funcSignature, statements...)
+}
+
+// needsValidation reports whether any non-ignored field needs a validation guard, which
+// means the builder's Build must return an error. The allArgs constructor does not consult
+// it and runs no validation.
+func needsValidation(fields []*fieldForConstructor) bool {
+	for _, field := range fields {
+		if field.shouldIgnore {
+			continue
+		}
+		if len(field.validators) > 0 || field.canValidate {
+			return true
+		}
+	}
+	return false
+}
+
+// generateValidationStatements turns a field's validation rules into `if ... { return
+// nil, err }` guards against varName, plus a Validate() call when canValidate is set.
+func generateValidationStatements(field *fieldForConstructor, varName string) []g.Statement {
+	statements := make([]g.Statement, 0)
+
+	for _, rule := range field.validators {
+		switch rule.name {
+		case "nonzero":
+			switch {
+			case isNilableFieldType(field.fieldType):
+				statements = append(statements, g.NewRawStatement(fmt.Sprintf(
+					`if %s == nil { return nil, fmt.Errorf("%s must not be nil") }`, varName, field.fieldName)))
+			case field.fieldType == "string":
+				statements = append(statements, g.NewRawStatement(fmt.Sprintf(
+					`if %s == "" { return nil, fmt.Errorf("%s must not be empty") }`, varName, field.fieldName)))
+			default:
+				statements = append(statements, g.NewRawStatement(fmt.Sprintf(
+					`if %s == 0 { return nil, fmt.Errorf("%s must not be zero") }`, varName, field.fieldName)))
+			}
+		case "min":
+			if isLengthedFieldType(field.fieldType) {
+				statements = append(statements, g.NewRawStatement(fmt.Sprintf(
+					`if len(%s) < %s { return nil, fmt.Errorf("%s must have length >= %s") }`, varName, rule.value, field.fieldName, rule.value)))
+			} else {
+				statements = append(statements, g.NewRawStatement(fmt.Sprintf(
+					`if %s < %s { return nil, fmt.Errorf("%s must be >= %s") }`, varName, rule.value, field.fieldName, rule.value)))
+			}
+		}
+	}
+
+	if field.canValidate {
+		statements = append(statements, g.NewRawStatement(fmt.Sprintf(
+			`if err := %s.Validate(); err != nil { return nil, fmt.Errorf("%s: %%w", err) }`, varName, field.fieldName)))
+	}
+
+	return statements
+}
+
+func isNilableFieldType(fieldType string) bool {
+	return strings.HasPrefix(fieldType, "*") ||
+		strings.HasPrefix(fieldType, "[]") ||
+		strings.HasPrefix(fieldType, "map[") ||
+		strings.HasPrefix(fieldType, "chan ") ||
+		strings.HasPrefix(fieldType, "func(") ||
+		fieldType == "interface{}" ||
+		fieldType == "any"
+}
+
+func isLengthedFieldType(fieldType string) bool {
+	return fieldType == "string" || strings.HasPrefix(fieldType, "[]") || strings.HasPrefix(fieldType, "map[")
+}
+
 func getConstructorTypes() ([]string, error) {
 	typs := strings.Split(*constructorTypes, ",")
 	for _, typ := range typs {
-		if typ != allArgsConstructorType && typ != builderConstructorType {
+		if typ != allArgsConstructorType && typ != builderConstructorType && typ != functionalOptionsConstructorType && typ != allArgsCheckedConstructorType {
 			return nil, fmt.Errorf("unexpected constructor type has come [given=%s]", typ)
 		}
 	}
+	if err := validateConstructorTypeCombination(typs); err != nil {
+		return nil, err
+	}
 	return typs, nil
 }
 
-var parsedFileCache = make(map[string]*ast.File)
+// namingConstructorTypes are the constructor types that each emit a top-level New<Type>
+// function; at most one of them may be requested together, since emitting more than one
+// produces a duplicate New<Type> declaration in the generated file. builderConstructorType
+// is exempt: it emits New<Type>Builder instead, so it can combine with any of these.
+var namingConstructorTypes = []string{allArgsConstructorType, functionalOptionsConstructorType, allArgsCheckedConstructorType}
 
-func parseFiles(files []string) ([]*ast.File, error) {
-	fset := token.NewFileSet()
-
-	astFiles := make([]*ast.File, len(files))
-	for i, file := range files {
-		if parsed := parsedFileCache[file]; parsed != nil {
-			astFiles[i] = parsed
-			continue
-		}
-
-		parsed, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse file: %w", err)
+func validateConstructorTypeCombination(typs []string) error {
+	var conflicting []string
+	for _, typ := range typs {
+		for _, naming := range namingConstructorTypes {
+			if typ == naming {
+				conflicting = append(conflicting, typ)
+			}
 		}
-		astFiles[i] = parsed
-		parsedFileCache[file] = parsed
 	}
-	return astFiles, nil
+	if len(conflicting) > 1 {
+		return fmt.Errorf("constructor types %s all emit New<Type> and can't be combined; pick one (builder may combine with any of them)", strings.Join(conflicting, ", "))
+	}
+	return nil
 }
 
-func extractFieldsForConstructorFromASTs(typeName string, astFiles []*ast.File) ([]*fieldForConstructor, error) {
+func extractFieldsForConstructorFromASTs(typeName string, astFiles []*ast.File, typesInfo *types.Info) ([]*fieldForConstructor, []*typeParam, error) {
 	for _, astFile := range astFiles {
 		for _, decl := range astFile.Decls {
 			genDecl, ok := decl.(*ast.GenDecl)
@@ -236,39 +570,181 @@ func extractFieldsForConstructorFromASTs(typeName string, astFiles []*ast.File)
 					continue
 				}
 
-				return correctFieldsForConstructor(structType.Fields.List), nil
+				fields := correctFieldsForConstructor(structType.Fields.List, typesInfo)
+				typeParams := extractTypeParams(typeSpec)
+				if err := validateFieldValidators(fields, typeParams); err != nil {
+					return nil, nil, err
+				}
+				return fields, typeParams, nil
+			}
+		}
+	}
+
+	return nil, nil, fmt.Errorf("there is no suitable struct that matches given typeName [given=%s]", typeName)
+}
+
+// validateFieldValidators rejects a `validate=` tag on a field whose type is one of the
+// struct's own type parameters, since rules like nonzero have no generic-safe zero value
+// to compare a bare type parameter against.
+func validateFieldValidators(fields []*fieldForConstructor, typeParams []*typeParam) error {
+	for _, field := range fields {
+		if len(field.validators) == 0 {
+			continue
+		}
+		for _, param := range typeParams {
+			if field.fieldType == param.name {
+				return fmt.Errorf("field %s: validate= is not supported on a type-parameter-typed field", field.fieldName)
 			}
 		}
 	}
+	return nil
+}
+
+// typeParam is one entry of `type Foo[T any] struct{...}`'s type parameter list.
+type typeParam struct {
+	name       string
+	constraint string
+}
+
+func extractTypeParams(typeSpec *ast.TypeSpec) []*typeParam {
+	if typeSpec.TypeParams == nil {
+		return nil
+	}
+
+	params := make([]*typeParam, 0)
+	for _, field := range typeSpec.TypeParams.List {
+		constraint := types.ExprString(field.Type)
+		for _, name := range field.Names {
+			params = append(params, &typeParam{name: name.Name, constraint: constraint})
+		}
+	}
+	return params
+}
+
+// typeParamsDecl renders e.g. `[T any, K comparable]`.
+func typeParamsDecl(params []*typeParam) string {
+	if len(params) == 0 {
+		return ""
+	}
+
+	decls := make([]string, len(params))
+	for i, param := range params {
+		decls[i] = fmt.Sprintf("%s %s", param.name, param.constraint)
+	}
+	return "[" + strings.Join(decls, ", ") + "]"
+}
+
+// typeParamsUse renders e.g. `[T, K]`.
+func typeParamsUse(params []*typeParam) string {
+	if len(params) == 0 {
+		return ""
+	}
 
-	return nil, fmt.Errorf("there is no suitable struct that matches given typeName [given=%s]", typeName)
+	names := make([]string, len(params))
+	for i, param := range params {
+		names[i] = param.name
+	}
+	return "[" + strings.Join(names, ", ") + "]"
 }
 
 type fieldForConstructor struct {
-	fieldName    string
+	fieldName string
+	// displayName is fieldName unless overridden by a -config rename; fieldName stays the
+	// real Go field for struct literals, displayName feeds derived identifiers.
+	displayName  string
 	fieldType    string
 	shouldIgnore bool
+	// required marks a positional (non-option) field under functionalOptionsConstructorType.
+	required   bool
+	validators []validationRule
+	// canValidate is true when the field's type has a Validate() error method.
+	canValidate bool
+}
+
+type validationRule struct {
+	name  string
+	value string
 }
 
-func correctFieldsForConstructor(fields []*ast.Field) []*fieldForConstructor {
+func correctFieldsForConstructor(fields []*ast.Field, typesInfo *types.Info) []*fieldForConstructor {
 	fs := make([]*fieldForConstructor, 0)
 	for _, field := range fields {
 		shouldIgnore := false
+		required := false
+		var validators []validationRule
 		if field.Tag != nil && len(field.Tag.Value) >= 1 {
 			customTag := reflect.StructTag(field.Tag.Value[1 : len(field.Tag.Value)-1])
-			shouldIgnore = customTag.Get(gonstructorTag) == "-"
+			tagValue := customTag.Get(gonstructorTag)
+			shouldIgnore = tagValue == "-"
+			required = tagValue == requiredFieldTagValue
+			validators = parseValidationRules(tagValue)
 		}
 
+		name := field.Names[0].Name
 		fs = append(fs, &fieldForConstructor{
-			fieldName:    field.Names[0].Name,
+			fieldName:    name,
+			displayName:  name,
 			fieldType:    types.ExprString(field.Type),
 			shouldIgnore: shouldIgnore,
+			required:     required,
+			validators:   validators,
+			canValidate:  fieldImplementsValidate(field, typesInfo),
 		})
 	}
 	return fs
 }
 
+func parseValidationRules(tagValue string) []validationRule {
+	if !strings.HasPrefix(tagValue, validateTagPrefix) {
+		return nil
+	}
+
+	rules := make([]validationRule, 0)
+	for _, rule := range strings.Split(strings.TrimPrefix(tagValue, validateTagPrefix), ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		name, value := rule, ""
+		if idx := strings.Index(rule, "="); idx >= 0 {
+			name, value = rule[:idx], rule[idx+1:]
+		}
+		rules = append(rules, validationRule{name: name, value: value})
+	}
+	return rules
+}
+
+// validateInterfaceType represents the `Validate() error` method gonstructor looks for on
+// a field's type before emitting an automatic validation call for it.
+var validateInterfaceType = types.NewInterfaceType([]*types.Func{
+	types.NewFunc(token.NoPos, nil, "Validate", types.NewSignatureType(
+		nil, nil, nil,
+		nil,
+		types.NewTuple(types.NewVar(token.NoPos, nil, "", types.Universe.Lookup("error").Type())),
+		false,
+	)),
+}, nil).Complete()
+
+func fieldImplementsValidate(field *ast.Field, typesInfo *types.Info) bool {
+	if typesInfo == nil || len(field.Names) == 0 {
+		return false
+	}
+
+	def, ok := typesInfo.Defs[field.Names[0]]
+	if !ok || def == nil {
+		return false
+	}
+
+	fieldType := def.Type()
+	return types.Implements(fieldType, validateInterfaceType) || types.Implements(types.NewPointer(fieldType), validateInterfaceType)
+}
+
 func parsePackage(patterns []string) (*packages.Package, error) {
+	return parsePackageWithEnv(patterns, nil)
+}
+
+// parsePackageWithEnv is parsePackage with an overridable build environment; see runWithContexts.
+func parsePackageWithEnv(patterns []string, env []string) (*packages.Package, error) {
 	cfg := &packages.Config{
 		Mode: packages.NeedName |
 			packages.NeedFiles |
@@ -279,6 +755,7 @@ func parsePackage(patterns []string) (*packages.Package, error) {
 			packages.NeedSyntax |
 			packages.NeedTypesInfo,
 		Tests: false,
+		Env:   env,
 	}
 	pkgs, err := packages.Load(cfg, patterns...)
 	if err != nil {
@@ -290,6 +767,148 @@ func parsePackage(patterns []string) (*packages.Package, error) {
 	return pkgs[0], nil
 }
 
+// buildContext is one entry of the -contexts flag, e.g. `linux-amd64` or `darwin-arm64-cgo`.
+type buildContext struct {
+	goos   string
+	goarch string
+	cgo    bool
+	label  string
+}
+
+func parseBuildContexts(raw string) ([]buildContext, error) {
+	parts := strings.Split(raw, ",")
+	contexts := make([]buildContext, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		fields := strings.Split(part, "-")
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("invalid context %q; expected <goos>-<goarch>[-cgo]", part)
+		}
+
+		ctx := buildContext{goos: fields[0], goarch: fields[1], label: part}
+		if len(fields) >= 3 && fields[2] == "cgo" {
+			ctx.cgo = true
+		}
+		contexts = append(contexts, ctx)
+	}
+
+	if len(contexts) == 0 {
+		return nil, fmt.Errorf("no build contexts given")
+	}
+	return contexts, nil
+}
+
+func (c buildContext) env() []string {
+	cgoEnabled := "0"
+	if c.cgo {
+		cgoEnabled = "1"
+	}
+	return append(os.Environ(), "GOOS="+c.goos, "GOARCH="+c.goarch, "CGO_ENABLED="+cgoEnabled)
+}
+
+func (c buildContext) buildTag() string {
+	tag := fmt.Sprintf("%s && %s", c.goos, c.goarch)
+	if c.cgo {
+		tag += " && cgo"
+	}
+	return tag
+}
+
+// runWithContexts scans typeName once per entry of -contexts. When every context agrees on
+// its field set, it writes a single unconditional <type>_gen.go, same as the single-context
+// path. Otherwise it writes one <type>_gen_<context>.go per context, each guarded by a
+// //go:build constraint and holding a constructor for that context's full field set; there
+// is deliberately no separate shared file for the common fields, since an unconditional
+// file and a per-context one can't both declare the same constructor without colliding
+// whenever both compile into the same build.
+func runWithContexts(typeName string, args []string, constructorTypes []string) error {
+	buildContexts, err := parseBuildContexts(*contexts)
+	if err != nil {
+		return err
+	}
+
+	var dir string
+	if len(args) == 1 && isDirectory(args[0]) {
+		dir = args[0]
+	} else {
+		dir = filepath.Dir(args[0])
+	}
+
+	packageName := ""
+	var typeParams []*typeParam
+	fieldsByContext := make(map[string][]*fieldForConstructor, len(buildContexts))
+	fieldKeysInOrder := make([]string, 0)
+	seenFieldKeys := make(map[string]bool)
+	presence := make(map[string]map[string]bool) // fieldKey -> set of context labels
+
+	for _, ctx := range buildContexts {
+		pkg, err := parsePackageWithEnv(args, ctx.env())
+		if err != nil {
+			return fmt.Errorf("failed to load package for context %s: %w", ctx.label, err)
+		}
+		packageName = pkg.Name
+
+		fields, ctxTypeParams, err := extractFieldsForConstructorFromASTs(typeName, pkg.Syntax, pkg.TypesInfo)
+		if err != nil {
+			return fmt.Errorf("failed to extract fields for context %s: %w", ctx.label, err)
+		}
+		fieldsByContext[ctx.label] = fields
+		typeParams = ctxTypeParams
+
+		for _, field := range fields {
+			key := field.fieldName + ":" + field.fieldType
+			if !seenFieldKeys[key] {
+				seenFieldKeys[key] = true
+				fieldKeysInOrder = append(fieldKeysInOrder, key)
+				presence[key] = make(map[string]bool)
+			}
+			presence[key][ctx.label] = true
+		}
+	}
+
+	universal := true
+	for _, key := range fieldKeysInOrder {
+		if len(presence[key]) != len(buildContexts) {
+			universal = false
+			break
+		}
+	}
+
+	invocation := strings.Join(os.Args[1:], " ")
+
+	if universal {
+		root := newGenerationRoot(packageName, invocation)
+		root = appendConstructorStatements(root, typeName, fieldsByContext[buildContexts[0].label], typeParams, constructorTypes)
+
+		filenameToGenerate := *output
+		if filenameToGenerate == "" {
+			filenameToGenerate = fmt.Sprintf("%s/%s_gen.go", dir, strcase.ToSnake(typeName))
+		}
+		return generateFile(root, filenameToGenerate)
+	}
+
+	for _, ctx := range buildContexts {
+		root := g.NewRoot(
+			g.NewComment(fmt.Sprintf(" Code generated by gonstructor %s; DO NOT EDIT.", invocation)),
+			g.NewRawStatement(fmt.Sprintf("//go:build %s", ctx.buildTag())),
+			g.NewNewline(),
+			g.NewPackage(packageName),
+			g.NewNewline(),
+		)
+		root = appendConstructorStatements(root, typeName, fieldsByContext[ctx.label], typeParams, constructorTypes)
+
+		filenameToGenerate := fmt.Sprintf("%s/%s_gen_%s.go", dir, strcase.ToSnake(typeName), strings.ReplaceAll(ctx.label, "-", "_"))
+		if err := generateFile(root, filenameToGenerate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func isDirectory(name string) bool {
 	info, err := os.Stat(name)
 	if err != nil {
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestApplyGenConfigOverrides_RenameKeepsRealFieldName(t *testing.T) {
+	fields := []*fieldForConstructor{
+		{fieldName: "ID", displayName: "ID", fieldType: "string"},
+	}
+	entry := genConfigEntry{Rename: map[string]string{"ID": "Identifier"}}
+
+	applyGenConfigOverrides(fields, entry)
+
+	if fields[0].fieldName != "ID" {
+		t.Fatalf("fieldName should stay the real Go field name, got %q", fields[0].fieldName)
+	}
+	if fields[0].displayName != "Identifier" {
+		t.Fatalf("displayName should take the rename, got %q", fields[0].displayName)
+	}
+
+	code, err := generateAllArgsConstructor("Opt", fields, nil).Generate(0)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if !strings.Contains(code, "ID:") {
+		t.Errorf("generated struct literal must key off the real field name ID, got:\n%s", code)
+	}
+	if strings.Contains(code, "Identifier:") {
+		t.Errorf("generated struct literal must not use the renamed display name as a struct key, got:\n%s", code)
+	}
+	if !strings.Contains(code, "identifier") {
+		t.Errorf("generated parameter should be named after the display name, got:\n%s", code)
+	}
+}
+
+func TestLoadGenConfig_DispatchesOnExtension(t *testing.T) {
+	yamlPath := writeTempFile(t, "config-*.yaml", "entries:\n- type: Foo\n")
+	cfg, err := loadGenConfig(yamlPath)
+	if err != nil {
+		t.Fatalf("loadGenConfig(yaml): %v", err)
+	}
+	if len(cfg.Entries) != 1 || cfg.Entries[0].Type != "Foo" {
+		t.Fatalf("unexpected yaml config: %+v", cfg)
+	}
+
+	tomlPath := writeTempFile(t, "config-*.toml", "[[entries]]\ntype = \"Foo\"\n")
+	cfg, err = loadGenConfig(tomlPath)
+	if err != nil {
+		t.Fatalf("loadGenConfig(toml): %v", err)
+	}
+	if len(cfg.Entries) != 1 || cfg.Entries[0].Type != "Foo" {
+		t.Fatalf("unexpected toml config: %+v", cfg)
+	}
+}
+
+func TestGenerateFunctionalOptionsConstructor_ReceiverOptsNameCollision(t *testing.T) {
+	fields := []*fieldForConstructor{
+		{fieldName: "ID", displayName: "ID", fieldType: "string", required: true},
+	}
+
+	code, err := generateFunctionalOptionsConstructor("Opt", fields, nil).Generate(0)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if strings.Contains(code, "opt(opt)") {
+		t.Errorf("loop variable must not shadow the receiver it calls, got:\n%s", code)
+	}
+}
+
+func TestParseBuildContexts(t *testing.T) {
+	contexts, err := parseBuildContexts("linux-amd64,darwin-arm64-cgo")
+	if err != nil {
+		t.Fatalf("parseBuildContexts: %v", err)
+	}
+	if len(contexts) != 2 {
+		t.Fatalf("expected 2 contexts, got %d", len(contexts))
+	}
+
+	if got, want := contexts[0].buildTag(), "linux && amd64"; got != want {
+		t.Errorf("buildTag() = %q, want %q", got, want)
+	}
+	if got, want := contexts[1].buildTag(), "darwin && arm64 && cgo"; got != want {
+		t.Errorf("buildTag() = %q, want %q", got, want)
+	}
+
+	env := contexts[1].env()
+	for _, want := range []string{"GOOS=darwin", "GOARCH=arm64", "CGO_ENABLED=1"} {
+		found := false
+		for _, e := range env {
+			if e == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("env() missing %q, got %v", want, env)
+		}
+	}
+
+	if _, err := parseBuildContexts(""); err == nil {
+		t.Error("expected an error for an empty -contexts value")
+	}
+}
+
+func TestGenerateAllArgsConstructor_Generics(t *testing.T) {
+	typeParams := []*typeParam{{name: "T", constraint: "any"}, {name: "K", constraint: "comparable"}}
+	fields := []*fieldForConstructor{
+		{fieldName: "Value", displayName: "Value", fieldType: "T"},
+	}
+
+	code, err := generateAllArgsConstructor("Box", fields, typeParams).Generate(0)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if !strings.Contains(code, "NewBox[T any, K comparable]") {
+		t.Errorf("expected a type-parameterized constructor signature, got:\n%s", code)
+	}
+	if !strings.Contains(code, "*Box[T, K]") {
+		t.Errorf("expected the return type to instantiate with bare type parameters, got:\n%s", code)
+	}
+}
+
+func TestFieldImplementsValidate_UsesTypeCheckedSyntax(t *testing.T) {
+	pkg, err := parsePackage([]string{"./testdata/validatefixture"})
+	if err != nil {
+		t.Fatalf("parsePackage: %v", err)
+	}
+
+	fields, _, err := extractFieldsForConstructorFromASTs("User", pkg.Syntax, pkg.TypesInfo)
+	if err != nil {
+		t.Fatalf("extractFieldsForConstructorFromASTs: %v", err)
+	}
+
+	var email *fieldForConstructor
+	for _, f := range fields {
+		if f.fieldName == "Email" {
+			email = f
+		}
+	}
+	if email == nil {
+		t.Fatal("Email field not found")
+	}
+	if !email.canValidate {
+		t.Error("Email field has a Validate() error method and should be detected as canValidate")
+	}
+}
+
+func TestValidateConstructorTypeCombination_RejectsMultipleNamingTypes(t *testing.T) {
+	cases := [][]string{
+		{allArgsConstructorType, functionalOptionsConstructorType},
+		{functionalOptionsConstructorType, allArgsCheckedConstructorType},
+		{allArgsConstructorType, allArgsCheckedConstructorType},
+	}
+	for _, typs := range cases {
+		if err := validateConstructorTypeCombination(typs); err == nil {
+			t.Errorf("expected an error for %v, got nil", typs)
+		}
+	}
+}
+
+func TestValidateConstructorTypeCombination_AllowsBuilderAlongsideAnyType(t *testing.T) {
+	cases := [][]string{
+		{allArgsConstructorType},
+		{allArgsConstructorType, builderConstructorType},
+		{functionalOptionsConstructorType, builderConstructorType},
+		{allArgsCheckedConstructorType, builderConstructorType},
+		{builderConstructorType},
+	}
+	for _, typs := range cases {
+		if err := validateConstructorTypeCombination(typs); err != nil {
+			t.Errorf("expected %v to be allowed, got %v", typs, err)
+		}
+	}
+}
+
+func TestValidateFieldValidators_RejectsValidateOnTypeParamField(t *testing.T) {
+	typeParams := []*typeParam{{name: "T", constraint: "any"}}
+	fields := []*fieldForConstructor{
+		{fieldName: "Value", displayName: "Value", fieldType: "T", validators: []validationRule{{name: "nonzero"}}},
+	}
+
+	if err := validateFieldValidators(fields, typeParams); err == nil {
+		t.Error("expected an error for validate= on a type-parameter-typed field")
+	}
+
+	fields[0].validators = nil
+	if err := validateFieldValidators(fields, typeParams); err != nil {
+		t.Errorf("expected no error once validators are removed, got %v", err)
+	}
+}
+
+func writeTempFile(t *testing.T, pattern, content string) string {
+	t.Helper()
+	f, err := ioutil.TempFile(t.TempDir(), pattern)
+	if err != nil {
+		t.Fatalf("create temp file: %v", err)
+	}
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatalf("write temp file: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("close temp file: %v", err)
+	}
+	return f.Name()
+}
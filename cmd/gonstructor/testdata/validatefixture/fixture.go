@@ -0,0 +1,14 @@
+package validatefixture
+
+// Email has a Validate() method, so a field of this type should be detected as
+// canValidate by fieldImplementsValidate.
+type Email struct {
+	addr string
+}
+
+func (e Email) Validate() error { return nil }
+
+type User struct {
+	Name  string
+	Email Email
+}